@@ -0,0 +1,129 @@
+// Copyright 2020 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin freebsd linux windows
+// +build !android
+// +build !ios
+
+package glfw
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
+)
+
+// FullscreenMode represents how SetFullscreen(true) puts the window into
+// fullscreen.
+type FullscreenMode int
+
+const (
+	// FullscreenModeWindowed means the window isn't fullscreen. It is the
+	// zero value so an unconfigured UserInterface reports "not fullscreen".
+	FullscreenModeWindowed FullscreenMode = iota
+
+	// FullscreenModeBorderlessWindowed resizes and positions the window to
+	// cover the target monitor at its current desktop resolution, without
+	// attaching the window to the monitor via glfw.SetMonitor. This is the
+	// default.
+	FullscreenModeBorderlessWindowed
+
+	// FullscreenModeExclusive hands the monitor over to the window via
+	// glfw.SetMonitor, switching to the video mode requested through
+	// SetFullscreenVideoMode (or the monitor's current one if none was
+	// requested). This can change the monitor's physical resolution and
+	// refresh rate for the duration of the fullscreen session.
+	FullscreenModeExclusive
+)
+
+// FullscreenMode returns the FullscreenMode that SetFullscreen(true) will use
+// (or is already using, while fullscreen).
+func (u *UserInterface) FullscreenMode() FullscreenMode {
+	if !u.isRunning() {
+		u.m.RLock()
+		defer u.m.RUnlock()
+		return u.fullscreenMode
+	}
+	var m FullscreenMode
+	_ = u.t.Call(func() error {
+		m = u.fullscreenMode
+		return nil
+	})
+	return m
+}
+
+// SetFullscreenMode sets the FullscreenMode that SetFullscreen(true) will
+// use. If the window is already fullscreen, it is switched to the new mode
+// immediately.
+func (u *UserInterface) SetFullscreenMode(mode FullscreenMode) {
+	if !u.isRunning() {
+		u.m.Lock()
+		u.fullscreenMode = mode
+		u.m.Unlock()
+		return
+	}
+	_ = u.t.Call(func() error {
+		if u.fullscreenMode == mode {
+			return nil
+		}
+		u.fullscreenMode = mode
+		if u.isFullscreen() {
+			// Width, height and the fullscreen flag are all unchanged here,
+			// so setWindowSize's own guard would otherwise treat this as a
+			// no-op and never switch borderless and exclusive fullscreen
+			// into each other.
+			u.forceSetWindowSize(u.windowWidth, u.windowHeight, true)
+		}
+		return nil
+	})
+}
+
+// SetFullscreenVideoMode requests the video mode to use the next time the
+// window enters FullscreenModeExclusive. A refreshRate of 0 lets the
+// platform pick its default rate for the given resolution.
+//
+// SetFullscreenVideoMode has no effect in FullscreenModeBorderlessWindowed,
+// where the window always matches the monitor's current desktop resolution.
+func (u *UserInterface) SetFullscreenVideoMode(width, height, refreshRate int) {
+	if !u.isRunning() {
+		u.m.Lock()
+		u.fullscreenVideoWidth = width
+		u.fullscreenVideoHeight = height
+		u.fullscreenVideoRefreshRate = refreshRate
+		u.m.Unlock()
+		return
+	}
+	_ = u.t.Call(func() error {
+		u.fullscreenVideoWidth = width
+		u.fullscreenVideoHeight = height
+		u.fullscreenVideoRefreshRate = refreshRate
+		if u.fullscreenMode == FullscreenModeExclusive && u.isFullscreen() {
+			// Same reasoning as in SetFullscreenMode: nothing setWindowSize
+			// checks has changed, so force it to re-apply the new video mode.
+			u.forceSetWindowSize(u.windowWidth, u.windowHeight, true)
+		}
+		return nil
+	})
+}
+
+// exclusiveVideoMode returns the width, height and refresh rate that
+// FullscreenModeExclusive should switch m to: the mode requested via
+// SetFullscreenVideoMode, or m's current video mode if none was requested.
+//
+// exclusiveVideoMode must be called on the main thread.
+func (u *UserInterface) exclusiveVideoMode(m *glfw.Monitor) (width, height, refreshRate int) {
+	if u.fullscreenVideoWidth > 0 && u.fullscreenVideoHeight > 0 {
+		return u.fullscreenVideoWidth, u.fullscreenVideoHeight, u.fullscreenVideoRefreshRate
+	}
+	v := m.GetVideoMode()
+	return v.Width, v.Height, v.RefreshRate
+}
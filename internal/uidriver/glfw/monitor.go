@@ -0,0 +1,312 @@
+// Copyright 2020 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin freebsd linux windows
+// +build !android
+// +build !ios
+
+package glfw
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
+)
+
+// Monitor represents a monitor available on the current platform.
+//
+// A Monitor value reflects the monitor configuration at the time it was
+// obtained from Monitors or Monitor; call those again after a monitor is
+// connected or disconnected to get fresh values.
+type Monitor struct {
+	m *glfw.Monitor
+
+	name       string
+	vm         *glfw.VidMode
+	vms        []*glfw.VidMode
+	x          int
+	y          int
+	workX      int
+	workY      int
+	workWidth  int
+	workHeight int
+	widthMM    int
+	heightMM   int
+	scaleX     float64
+	scaleY     float64
+}
+
+// Name returns a human-readable name for the monitor, as reported by the OS.
+func (m *Monitor) Name() string {
+	return m.name
+}
+
+// Position returns the monitor's position in the virtual desktop coordinate
+// space shared by all monitors.
+func (m *Monitor) Position() (int, int) {
+	return m.x, m.y
+}
+
+// Size returns the monitor's current resolution in device-dependent pixels.
+func (m *Monitor) Size() (int, int) {
+	return m.vm.Width, m.vm.Height
+}
+
+// RefreshRate returns the monitor's current refresh rate in Hz.
+func (m *Monitor) RefreshRate() int {
+	return m.vm.RefreshRate
+}
+
+// VideoModes returns the video modes the monitor supports, as reported by
+// GLFW, so a game can let the user pick a specific resolution and refresh
+// rate for exclusive fullscreen.
+func (m *Monitor) VideoModes() []*glfw.VidMode {
+	return m.vms
+}
+
+// WorkArea returns the monitor's work area in the virtual desktop coordinate
+// space: its bounds minus space reserved by the OS, such as taskbars and
+// menu bars.
+func (m *Monitor) WorkArea() (x, y, width, height int) {
+	return m.workX, m.workY, m.workWidth, m.workHeight
+}
+
+// PhysicalSize returns the monitor's physical size in millimeters, as
+// reported by the OS.
+func (m *Monitor) PhysicalSize() (width, height int) {
+	return m.widthMM, m.heightMM
+}
+
+// ContentScale returns the ratio between the monitor's current DPI and the
+// platform's default DPI, which games can use as an additional signal
+// alongside DeviceScaleFactor when laying out UI for a specific monitor.
+func (m *Monitor) ContentScale() (x, y float64) {
+	return m.scaleX, m.scaleY
+}
+
+func (c *cachedMonitor) toMonitor() *Monitor {
+	return &Monitor{
+		m:          c.m,
+		name:       c.name,
+		vm:         c.vm,
+		vms:        c.vms,
+		x:          c.x,
+		y:          c.y,
+		workX:      c.workX,
+		workY:      c.workY,
+		workWidth:  c.workWidth,
+		workHeight: c.workHeight,
+		widthMM:    c.widthMM,
+		heightMM:   c.heightMM,
+		scaleX:     c.scaleX,
+		scaleY:     c.scaleY,
+	}
+}
+
+// Monitors returns the monitors currently connected, in the order reported
+// by the OS.
+//
+// TODO: expose this through driver.UIContext and the ebiten package so games
+// can call ebiten.Monitors().
+func (u *UserInterface) Monitors() []*Monitor {
+	u.m.RLock()
+	defer u.m.RUnlock()
+
+	ms := make([]*Monitor, 0, len(monitors))
+	for _, c := range monitors {
+		ms = append(ms, c.toMonitor())
+	}
+	return ms
+}
+
+// Monitor returns the monitor selected via SetMonitor, or else the monitor
+// the window currently belongs to. If the main loop hasn't started yet, it
+// returns the monitor selected via SetMonitor or detected at initialization.
+func (u *UserInterface) Monitor() *Monitor {
+	if !u.isRunning() {
+		u.m.RLock()
+		m := u.initMonitor
+		u.m.RUnlock()
+		if c := getCachedMonitorFromGLFW(m); c != nil {
+			return c.toMonitor()
+		}
+		return nil
+	}
+
+	var m *Monitor
+	_ = u.t.Call(func() error {
+		if c := getCachedMonitorFromGLFW(u.targetMonitor()); c != nil {
+			m = c.toMonitor()
+		}
+		return nil
+	})
+	return m
+}
+
+// SetMonitor pins the window, and any future fullscreen transition, to the
+// given monitor instead of letting it be inferred from the window's
+// position. Passing nil restores the default, position-based behavior.
+//
+// While the window is not fullscreen, SetMonitor does not move it; the
+// selected monitor is used by Monitor, ScreenSizeInFullscreen and the next
+// fullscreen transition.
+//
+// TODO: expose this through driver.UIContext and the ebiten package so games
+// can call Window.SetMonitor().
+func (u *UserInterface) SetMonitor(monitor *Monitor) {
+	if !u.isRunning() {
+		u.m.Lock()
+		if monitor != nil {
+			u.initMonitor = monitor.m
+			u.monitor = monitor.m
+		} else {
+			u.initMonitor = glfw.GetPrimaryMonitor()
+			u.monitor = nil
+		}
+		u.m.Unlock()
+		return
+	}
+
+	var m *glfw.Monitor
+	if monitor != nil {
+		m = monitor.m
+	}
+
+	_ = u.t.Call(func() error {
+		u.monitor = m
+		if u.isFullscreen() {
+			// setWindowSize's guard only looks at width, height, the
+			// fullscreen flag and the scale factor, none of which change
+			// when only the pinned monitor does, so force it to pick up
+			// the new u.monitor and actually move the window.
+			u.forceSetWindowSize(u.windowWidth, u.windowHeight, true)
+		} else {
+			u.setWindowSize(u.windowWidth, u.windowHeight, false)
+		}
+		return nil
+	})
+}
+
+// clearPinnedMonitorIfDisconnected resets the monitor pinned via SetMonitor,
+// or the monitor queued via SetMonitor before the window was created, if it's
+// the one that just disconnected. Otherwise targetMonitor and Monitor would
+// keep handing out a *glfw.Monitor that's no longer valid, which is undefined
+// behavior per GLFW's own contract.
+//
+// clearPinnedMonitorIfDisconnected must be called on the main thread.
+func (u *UserInterface) clearPinnedMonitorIfDisconnected(disconnected *glfw.Monitor) {
+	if u.monitor == disconnected {
+		u.monitor = nil
+	}
+	if u.lastActiveMonitor == disconnected {
+		u.lastActiveMonitor = nil
+	}
+
+	u.m.Lock()
+	if u.initMonitor == disconnected {
+		u.initMonitor = glfw.GetPrimaryMonitor()
+	}
+	u.m.Unlock()
+}
+
+// MonitorEvent describes a monitor connectivity change, as reported by GLFW.
+type MonitorEvent int
+
+const (
+	MonitorConnected MonitorEvent = iota
+	MonitorDisconnected
+)
+
+// SetMonitorCallback registers fn to be invoked, on the main thread,
+// whenever a monitor is connected or disconnected. monitor is nil for a
+// MonitorDisconnected event if the disconnected monitor was never cached
+// (e.g. it registers and unregisters before any cacheMonitors call observes
+// it), which should not happen in practice but is handled defensively.
+//
+// SetMonitorCallback replaces any previously registered callback.
+func (u *UserInterface) SetMonitorCallback(fn func(monitor *Monitor, event MonitorEvent)) {
+	u.m.Lock()
+	u.monitorCallback = fn
+	u.m.Unlock()
+}
+
+// notifyMonitorEvent invokes the registered monitor callback, if any. c may
+// be nil; see SetMonitorCallback.
+//
+// notifyMonitorEvent must be called on the main thread.
+func (u *UserInterface) notifyMonitorEvent(c *cachedMonitor, event glfw.PeripheralEvent) {
+	u.m.RLock()
+	fn := u.monitorCallback
+	u.m.RUnlock()
+	if fn == nil {
+		return
+	}
+
+	var e MonitorEvent
+	switch event {
+	case glfw.Connected:
+		e = MonitorConnected
+	case glfw.Disconnected:
+		e = MonitorDisconnected
+	}
+
+	var m *Monitor
+	if c != nil {
+		m = c.toMonitor()
+	}
+	fn(m, e)
+}
+
+// SetActiveMonitorChangedCallback registers fn to be invoked, on the main
+// thread, whenever the monitor returned by Monitor changes, e.g. because the
+// window was dragged to another display or SetMonitor was called. A change
+// caused by disconnecting the previously active monitor is not reported:
+// the next monitor only becomes the new baseline.
+//
+// SetActiveMonitorChangedCallback replaces any previously registered
+// callback.
+func (u *UserInterface) SetActiveMonitorChangedCallback(fn func(old, next *Monitor)) {
+	u.m.Lock()
+	u.activeMonitorChangedCallback = fn
+	u.m.Unlock()
+}
+
+// updateActiveMonitor notifies the active-monitor-changed callback, if any,
+// when the monitor returned by Monitor has changed since the last call.
+//
+// updateActiveMonitor must be called on the main thread.
+func (u *UserInterface) updateActiveMonitor() {
+	cur := u.targetMonitor()
+	if cur == u.lastActiveMonitor {
+		return
+	}
+	old := u.lastActiveMonitor
+	u.lastActiveMonitor = cur
+
+	u.m.RLock()
+	fn := u.activeMonitorChangedCallback
+	u.m.RUnlock()
+	// The first observation just establishes a baseline; there is no
+	// previous monitor to report a transition from.
+	if fn == nil || old == nil {
+		return
+	}
+
+	var oldMonitor, newMonitor *Monitor
+	if c := getCachedMonitorFromGLFW(old); c != nil {
+		oldMonitor = c.toMonitor()
+	}
+	if c := getCachedMonitorFromGLFW(cur); c != nil {
+		newMonitor = c.toMonitor()
+	}
+	fn(oldMonitor, newMonitor)
+}
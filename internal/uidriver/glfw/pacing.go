@@ -0,0 +1,145 @@
+// Copyright 2020 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin freebsd linux windows
+// +build !android
+// +build !ios
+
+package glfw
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// TargetFPSVsyncOnly throttles the loop via glfw's vsync/swap-interval
+	// wait. Where that wait is off (vsync disabled, or
+	// SwapIntervalPacedUncapped), the loop is paced in software instead; see
+	// SetTargetFPS and paceFrame. This is the default.
+	TargetFPSVsyncOnly = -1
+
+	// TargetFPSUnlimited disables frame-rate throttling entirely; the loop
+	// runs as fast as SwapBuffers and glfw.PollEvents allow.
+	TargetFPSUnlimited = -2
+)
+
+// SetTargetFPS sets the target frame rate for the main loop: TargetFPSVsyncOnly,
+// TargetFPSUnlimited, or a fixed number of frames per second (fps > 0).
+//
+// A fixed target is enforced by framePacer regardless of the vsync setting,
+// so SetVsyncEnabled(false) paired with a fixed target still caps the loop.
+// TargetFPSVsyncOnly additionally falls back to a 60fps pace whenever vsync
+// is disabled, so turning vsync off alone doesn't produce an uncapped busy
+// loop.
+//
+// TODO: expose this through driver.UIContext and the ebiten package so games
+// can call ebiten.SetTargetFPS().
+func (u *UserInterface) SetTargetFPS(fps int) {
+	u.m.Lock()
+	u.targetFPS = fps
+	u.m.Unlock()
+	u.framePacer.reset()
+}
+
+// TargetFPS returns the value last passed to SetTargetFPS.
+func (u *UserInterface) TargetFPS() int {
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.targetFPS
+}
+
+// paceFrame sleeps as needed so the loop doesn't run faster than
+// u.targetFPS. It is called once per iteration of loop in which the window
+// is focused, after swapping buffers, from the same goroutine as loop (not
+// the main thread).
+func (u *UserInterface) paceFrame() {
+	// u.targetFPS is read through TargetFPS, not the field directly: it's
+	// written by SetTargetFPS from its caller's goroutine, while paceFrame
+	// runs on loop's own goroutine.
+	targetFPS := u.TargetFPS()
+	switch {
+	case targetFPS == TargetFPSUnlimited:
+		return
+	case targetFPS == TargetFPSVsyncOnly:
+		if !u.IsVsyncEnabled() {
+			// Vsync is off: pace against a sane default so this doesn't turn
+			// into a busy loop.
+			u.framePacer.wait(60)
+			return
+		}
+		if u.SwapInterval() != SwapIntervalPacedUncapped {
+			// SwapBuffers already blocks for vsync.
+			return
+		}
+		// SwapIntervalPacedUncapped asked glfw.SwapInterval(0) to stop
+		// blocking; pace against the monitor's own refresh rate instead.
+		fps := 60
+		_ = u.t.Call(func() error {
+			if v := u.targetMonitor().GetVideoMode(); v.RefreshRate > 0 {
+				fps = v.RefreshRate
+			}
+			return nil
+		})
+		u.framePacer.wait(fps)
+	default:
+		u.framePacer.wait(targetFPS)
+	}
+}
+
+// framePacer sleeps between frames to hit a fixed target FPS using a
+// monotonic deadline, so per-frame scheduling error doesn't accumulate the
+// way a fixed time.Sleep(time.Second/fps) would.
+//
+// wait runs on loop's own goroutine while reset is called from other
+// goroutines (SetTargetFPS from its caller's goroutine, SetVsyncEnabled and
+// SetSwapInterval from the main thread), so next is guarded by mu rather than
+// relying on either side's confinement.
+type framePacer struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next frame's deadline and advances it by exactly one
+// frame interval, unless the loop has fallen far enough behind (e.g. after a
+// long stall) that the deadline is reset from now instead.
+func (p *framePacer) wait(fps int) {
+	if fps <= 0 {
+		return
+	}
+
+	interval := time.Second / time.Duration(fps)
+	now := time.Now()
+
+	p.mu.Lock()
+	if p.next.IsZero() || now.Sub(p.next) > interval {
+		p.next = now.Add(interval)
+	} else {
+		p.next = p.next.Add(interval)
+	}
+	d := p.next.Sub(now)
+	p.mu.Unlock()
+
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+// reset clears the pacer's deadline, e.g. after the target FPS or the vsync
+// setting changes.
+func (p *framePacer) reset() {
+	p.mu.Lock()
+	p.next = time.Time{}
+	p.mu.Unlock()
+}
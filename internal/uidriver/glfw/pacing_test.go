@@ -0,0 +1,82 @@
+// Copyright 2020 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin freebsd linux windows
+// +build !android
+// +build !ios
+
+package glfw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFramePacerWaitNonPositiveFPSNoop(t *testing.T) {
+	var p framePacer
+	start := time.Now()
+	p.wait(0)
+	p.wait(-1)
+	if time.Since(start) > time.Millisecond {
+		t.Errorf("wait with fps <= 0 should return immediately, took %v", time.Since(start))
+	}
+	if !p.next.IsZero() {
+		t.Errorf("wait with fps <= 0 should not touch the deadline")
+	}
+}
+
+func TestFramePacerWaitAdvancesByInterval(t *testing.T) {
+	var p framePacer
+	const fps = 20
+	interval := time.Second / fps
+
+	p.wait(fps)
+	first := p.next
+
+	p.wait(fps)
+	second := p.next
+
+	if got := second.Sub(first); got != interval {
+		t.Errorf("deadline should advance by exactly one interval, got %v want %v", got, interval)
+	}
+}
+
+func TestFramePacerWaitResetsAfterStall(t *testing.T) {
+	var p framePacer
+	const fps = 20
+	interval := time.Second / fps
+
+	// Simulate a long stall: the deadline is far enough in the past that
+	// catching up frame-by-frame would mean not sleeping at all for many
+	// iterations. wait should instead re-baseline from now.
+	stalled := time.Now().Add(-10 * interval)
+	p.next = stalled
+
+	before := time.Now()
+	p.wait(fps)
+	after := time.Now()
+
+	if p.next.Before(before) || p.next.After(after.Add(interval)) {
+		t.Errorf("deadline should be rebased from now after a long stall, got %v for window [%v, %v]", p.next, before, after.Add(interval))
+	}
+}
+
+func TestFramePacerReset(t *testing.T) {
+	var p framePacer
+	p.next = time.Now().Add(time.Hour)
+	p.reset()
+	if !p.next.IsZero() {
+		t.Errorf("reset should clear the deadline")
+	}
+}
@@ -50,16 +50,65 @@ type UserInterface struct {
 	origPosY            int
 	runnableOnUnfocused bool
 	vsync               bool
-	iconImages          []image.Image
+	// swapInterval is the requested glfw.SwapInterval argument while vsync
+	// is enabled: 1 (default), SwapIntervalAdaptive, an explicit value > 1,
+	// or SwapIntervalPacedUncapped. See SetSwapInterval.
+	swapInterval int
+	iconImages   []image.Image
+
+	// fullscreenMode is the mode requested for the next (or current)
+	// fullscreen transition.
+	fullscreenMode FullscreenMode
+
+	// activeFullscreenMode is the mode actually in effect while
+	// isFullscreen() is true, so leaving fullscreen can undo whichever of
+	// the FullscreenMode variants put the window there.
+	activeFullscreenMode FullscreenMode
+
+	// fullscreenVideoWidth, fullscreenVideoHeight and
+	// fullscreenVideoRefreshRate are the video mode requested via
+	// SetFullscreenVideoMode for FullscreenModeExclusive. Zero width/height
+	// means "use the monitor's current video mode", matching the historical
+	// behavior of this package.
+	fullscreenVideoWidth       int
+	fullscreenVideoHeight      int
+	fullscreenVideoRefreshRate int
+
+	// targetFPS is TargetFPSVsyncOnly, TargetFPSUnlimited, or a fixed target
+	// frame rate in frames per second. See SetTargetFPS.
+	targetFPS int
+
+	// framePacer paces the loop towards targetFPS when relying on vsync
+	// alone wouldn't (see paceFrame).
+	framePacer framePacer
 
 	// err must be accessed from the main thread.
 	err error
 
 	lastDeviceScaleFactor float64
 
+	// monitor is the monitor explicitly selected via SetMonitor. If nil, the
+	// monitor used for sizing and fullscreen operations is inferred from the
+	// window's position (see currentMonitor).
+	monitor *glfw.Monitor
+
+	// lastActiveMonitor is the monitor returned by Monitor as of the last
+	// updateActiveMonitor call, used to detect changes to notify via
+	// activeMonitorChangedCallback.
+	lastActiveMonitor *glfw.Monitor
+
+	monitorCallback              func(monitor *Monitor, event MonitorEvent)
+	activeMonitorChangedCallback func(old, next *Monitor)
+
+	// initMonitor is the monitor detected at initialization, or the one
+	// selected via SetMonitor before the main loop starts; DeviceScaleFactor
+	// and Monitor use it until the main loop starts. Unlike the other init*
+	// fields below, it can change after initialization: SetMonitor and
+	// clearPinnedMonitorIfDisconnected both update it pre-Run.
+	initMonitor *glfw.Monitor
+
 	// These values are not changed after initialized.
 	// TODO: the fullscreen size should be updated when the initial window position is changed?
-	initMonitor              *glfw.Monitor
 	initFullscreenWidthInDP  int
 	initFullscreenHeightInDP int
 
@@ -107,6 +156,9 @@ var (
 		initWindowHeightInDP:    480,
 		initFocused:             true,
 		vsync:                   true,
+		swapInterval:            1,
+		fullscreenMode:          FullscreenModeBorderlessWindowed,
+		targetFPS:               TargetFPSVsyncOnly,
 	}
 )
 
@@ -125,7 +177,19 @@ func init() {
 		panic(err)
 	}
 	glfw.SetMonitorCallback(func(monitor *glfw.Monitor, event glfw.PeripheralEvent) {
+		// A monitor being disconnected is about to disappear from the cache,
+		// so it must be looked up before cacheMonitors refreshes it. A newly
+		// connected monitor, conversely, is only in the cache afterwards.
+		var c *cachedMonitor
+		if event == glfw.Disconnected {
+			c = getCachedMonitorFromGLFW(monitor)
+			theUI.clearPinnedMonitorIfDisconnected(monitor)
+		}
 		cacheMonitors()
+		if event == glfw.Connected {
+			c = getCachedMonitorFromGLFW(monitor)
+		}
+		theUI.notifyMonitorEvent(c, event)
 	})
 	cacheMonitors()
 }
@@ -161,9 +225,26 @@ func initialize() error {
 type cachedMonitor struct {
 	m  *glfw.Monitor
 	vm *glfw.VidMode
+	// name is a human-readable monitor name as reported by the OS.
+	name string
+	// vms is the list of video modes the monitor supports.
+	vms []*glfw.VidMode
 	// Pos of monitor in virtual coords
 	x int
 	y int
+	// Work area of the monitor in virtual coords, i.e. the monitor's bounds
+	// minus space reserved by the OS (taskbars, docks, menu bars, ...).
+	workX int
+	workY int
+	workWidth  int
+	workHeight int
+	// Physical size of the monitor in millimeters, as reported by the OS.
+	widthMM  int
+	heightMM int
+	// Content scale, i.e. the ratio between the current DPI and the
+	// platform's default DPI.
+	scaleX float64
+	scaleY float64
 }
 
 // monitors is the monitor list cache for desktop glfw compile targets.
@@ -178,15 +259,42 @@ func cacheMonitors() {
 	ms := glfw.GetMonitors()
 	for _, m := range ms {
 		x, y := m.GetPos()
+		wx, wy, ww, wh := m.GetWorkarea()
+		mmWidth, mmHeight := m.GetPhysicalSize()
+		sx, sy := m.GetContentScale()
 		monitors = append(monitors, &cachedMonitor{
-			m:  m,
-			vm: m.GetVideoMode(),
-			x:  x,
-			y:  y,
+			m:          m,
+			vm:         m.GetVideoMode(),
+			name:       m.GetName(),
+			vms:        m.GetVideoModes(),
+			x:          x,
+			y:          y,
+			workX:      wx,
+			workY:      wy,
+			workWidth:  ww,
+			workHeight: wh,
+			widthMM:    mmWidth,
+			heightMM:   mmHeight,
+			scaleX:     sx,
+			scaleY:     sy,
 		})
 	}
 }
 
+// getCachedMonitorFromGLFW returns the cachedMonitor wrapping the given
+// *glfw.Monitor, or nil if it is not currently known (e.g. it was just
+// disconnected).
+//
+// getCachedMonitorFromGLFW must be called on the main thread.
+func getCachedMonitorFromGLFW(m *glfw.Monitor) *cachedMonitor {
+	for _, c := range monitors {
+		if c.m == m {
+			return c
+		}
+	}
+	return nil
+}
+
 // getCachedMonitor returns a monitor for the given window x/y,
 // or returns nil if monitor is not found.
 //
@@ -399,7 +507,7 @@ func (u *UserInterface) ScreenSizeInFullscreen() (int, int) {
 
 	var w, h int
 	_ = u.t.Call(func() error {
-		v := currentMonitor(u.window).GetVideoMode()
+		v := u.targetMonitor().GetVideoMode()
 		s := u.deviceScaleFactor()
 		w = int(fromGLFWMonitorPixel(float64(v.Width), s))
 		h = int(fromGLFWMonitorPixel(float64(v.Height), s))
@@ -413,7 +521,11 @@ func (u *UserInterface) isFullscreen() bool {
 	if !u.isRunning() {
 		panic("glfw: isFullscreen can't be called before the main loop starts")
 	}
-	return u.window.GetMonitor() != nil
+	// FullscreenModeBorderlessWindowed never attaches the window to a
+	// monitor via glfw.SetMonitor, so
+	// GetMonitor alone can't tell a borderless fullscreen window from a
+	// windowed one that merely happens to cover the monitor.
+	return u.window.GetMonitor() != nil || u.activeFullscreenMode == FullscreenModeBorderlessWindowed
 }
 
 func (u *UserInterface) IsFullscreen() bool {
@@ -491,6 +603,10 @@ func (u *UserInterface) SetVsyncEnabled(enabled bool) {
 		}
 		u.vsync = enabled
 		u.updateVsync()
+		// The vsync wait this toggles is what TargetFPSVsyncOnly leans on;
+		// reset the pacer so a stale deadline doesn't cause a frame-long
+		// stall or burst right after the switch.
+		u.framePacer.reset()
 		return nil
 	})
 }
@@ -771,7 +887,7 @@ func (u *UserInterface) updateSize() (float64, float64, bool) {
 
 	var w, h float64
 	if u.isFullscreen() {
-		v := currentMonitor(u.window).GetVideoMode()
+		v := u.targetMonitor().GetVideoMode()
 		ww, wh := v.Width, v.Height
 		s := u.deviceScaleFactor()
 		w = fromGLFWMonitorPixel(float64(ww), s)
@@ -817,6 +933,8 @@ func (u *UserInterface) update() (float64, float64, bool, error) {
 
 	outsideWidth, outsideHeight, outsideSizeChanged := u.updateSize()
 
+	u.updateActiveMonitor()
+
 	// TODO: Updating the input can be skipped when clock.Update returns 0 (#1367).
 	glfw.PollEvents()
 	u.input.update(u.window, u.context)
@@ -924,6 +1042,8 @@ func (u *UserInterface) loop() error {
 			if d < wait {
 				time.Sleep(wait - d)
 			}
+		} else {
+			u.paceFrame()
 		}
 	}
 }
@@ -937,7 +1057,28 @@ func (u *UserInterface) swapBuffers() {
 
 // setWindowSize must be called from the main thread.
 func (u *UserInterface) setWindowSize(width, height int, fullscreen bool) {
-	if u.windowWidth == width && u.windowHeight == height && u.isFullscreen() == fullscreen && u.lastDeviceScaleFactor == u.deviceScaleFactor() {
+	u.setWindowSizeImpl(width, height, fullscreen, false)
+}
+
+// forceSetWindowSize re-applies the window size/fullscreen state even if
+// width, height, the fullscreen flag and the device scale factor all match
+// what's already in effect. This is needed whenever something setWindowSize's
+// early-return guard can't see has changed: the FullscreenMode, the pinned
+// monitor (SetMonitor), or the requested exclusive video mode
+// (SetFullscreenVideoMode).
+//
+// forceSetWindowSize must be called from the main thread.
+func (u *UserInterface) forceSetWindowSize(width, height int, fullscreen bool) {
+	u.setWindowSizeImpl(width, height, fullscreen, true)
+}
+
+// setWindowSizeImpl must be called from the main thread.
+func (u *UserInterface) setWindowSizeImpl(width, height int, fullscreen, force bool) {
+	// FullscreenModeWindowed means the window isn't fullscreen.
+	if u.fullscreenMode == FullscreenModeWindowed {
+		fullscreen = false
+	}
+	if !force && u.windowWidth == width && u.windowHeight == height && u.isFullscreen() == fullscreen && u.lastDeviceScaleFactor == u.deviceScaleFactor() {
 		return
 	}
 
@@ -966,9 +1107,36 @@ func (u *UserInterface) setWindowSize(width, height int, fullscreen bool) {
 		if u.origPosX == invalidPos || u.origPosY == invalidPos {
 			u.origPosX, u.origPosY = u.window.GetPos()
 		}
-		m := currentMonitor(u.window)
-		v := m.GetVideoMode()
-		u.window.SetMonitor(m, 0, 0, v.Width, v.Height, v.RefreshRate)
+		m := u.targetMonitor()
+		switch u.fullscreenMode {
+		case FullscreenModeExclusive:
+			// Leaving exclusive fullscreen doesn't restore decorations, so
+			// put back the ones a previous borderless session stripped.
+			if u.activeFullscreenMode == FullscreenModeBorderlessWindowed && u.isInitWindowDecorated() {
+				u.window.SetAttrib(glfw.Decorated, glfw.True)
+			}
+			vw, vh, vr := u.exclusiveVideoMode(m)
+			u.window.SetMonitor(m, 0, 0, vw, vh, vr)
+		default:
+			// Borderless: cover the monitor at its current desktop
+			// resolution, without handing it over via SetMonitor. Strip the
+			// title bar and border first, or the window would just look like
+			// a maximized decorated window instead of a fullscreen one.
+			if u.window.GetAttrib(glfw.Decorated) == glfw.True {
+				u.window.SetAttrib(glfw.Decorated, glfw.False)
+			}
+			mx, my := m.GetPos()
+			v := m.GetVideoMode()
+			// A window coming from FullscreenModeExclusive is still attached
+			// to a monitor; GLFW ignores SetPos/SetSize for such a window, so
+			// it must be detached first or the transition would do nothing.
+			if u.window.GetMonitor() != nil {
+				u.window.SetMonitor(nil, mx, my, v.Width, v.Height, 0)
+			}
+			u.window.SetPos(mx, my)
+			u.window.SetSize(v.Width, v.Height)
+		}
+		u.activeFullscreenMode = u.fullscreenMode
 
 		// Swapping buffer is necesary to prevent the image lag (#1004).
 		// TODO: This might not work when vsync is disabled.
@@ -988,7 +1156,7 @@ func (u *UserInterface) setWindowSize(width, height int, fullscreen bool) {
 			width = minWindowWidth
 		}
 
-		if u.window.GetMonitor() != nil {
+		if u.activeFullscreenMode == FullscreenModeExclusive {
 			if u.Graphics().IsGL() {
 				// When OpenGL is used, swapping buffer is enough to solve the image-lag
 				// issue (#1004). Rather, recreating window destroys GPU resources.
@@ -1011,6 +1179,10 @@ func (u *UserInterface) setWindowSize(width, height int, fullscreen bool) {
 				windowRecreated = true
 			}
 		}
+		if u.activeFullscreenMode == FullscreenModeBorderlessWindowed && u.isInitWindowDecorated() {
+			u.window.SetAttrib(glfw.Decorated, glfw.True)
+		}
+		u.activeFullscreenMode = FullscreenModeWindowed
 
 		if u.origPosX != invalidPos && u.origPosY != invalidPos {
 			x := u.origPosX
@@ -1072,17 +1244,28 @@ func (u *UserInterface) updateVsync() {
 		// SwapInterval is affected by the current monitor of the window.
 		// This needs to be called at least after SetMonitor.
 		// Without SwapInterval after SetMonitor, vsynch doesn't work (#375).
-		//
-		// TODO: (#405) If triple buffering is needed, SwapInterval(0) should be called,
-		// but is this correct? If glfw.SwapInterval(0) and the driver doesn't support triple
-		// buffering, what will happen?
-		if u.vsync {
-			glfw.SwapInterval(1)
-		} else {
+		switch {
+		case !u.vsync, u.swapInterval == SwapIntervalPacedUncapped:
+			// SwapIntervalPacedUncapped still turns the driver's wait off;
+			// paceFrame takes over pacing against the monitor's refresh rate.
 			glfw.SwapInterval(0)
+		case u.swapInterval == SwapIntervalAdaptive:
+			// Requires EXT_swap_control_tear / WGL_EXT_swap_control_tear.
+			// Where the extension is unavailable, GLFW falls back to regular
+			// vsync (interval 1), which is an acceptable fallback here.
+			glfw.SwapInterval(-1)
+		default:
+			interval := u.swapInterval
+			if interval < 1 {
+				interval = 1
+			}
+			glfw.SwapInterval(interval)
 		}
 	}
 	u.Graphics().SetVsyncEnabled(u.vsync)
+	if g, ok := u.Graphics().(interface{ SetSwapInterval(int) }); ok {
+		g.SetSwapInterval(u.swapInterval)
+	}
 }
 
 // currentMonitor returns the current active monitor.
@@ -1110,6 +1293,18 @@ func currentMonitor(window *glfw.Window) *glfw.Monitor {
 	return glfw.GetPrimaryMonitor()
 }
 
+// targetMonitor returns the monitor that window-sizing and fullscreen
+// operations should use: the one explicitly selected via SetMonitor, or the
+// one inferred from the window's current position.
+//
+// targetMonitor must be called on the main thread.
+func (u *UserInterface) targetMonitor() *glfw.Monitor {
+	if u.monitor != nil {
+		return u.monitor
+	}
+	return currentMonitor(u.window)
+}
+
 func (u *UserInterface) SetScreenTransparent(transparent bool) {
 	if !u.isRunning() {
 		u.setInitScreenTransparent(transparent)
@@ -0,0 +1,82 @@
+// Copyright 2020 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin freebsd linux windows
+// +build !android
+// +build !ios
+
+package glfw
+
+const (
+	// SwapIntervalAdaptive requests adaptive vsync via glfw.SwapInterval(-1):
+	// vsync while a frame lands on time, an immediate swap instead of
+	// waiting a full extra refresh if it missed the blank. It relies on the
+	// EXT_swap_control_tear / WGL_EXT_swap_control_tear extensions; where
+	// they're unavailable, GLFW falls back to a regular swap interval of 1.
+	SwapIntervalAdaptive = -1
+
+	// SwapIntervalPacedUncapped turns the driver's vsync wait off
+	// (glfw.SwapInterval(0)) and instead, while the target FPS is
+	// TargetFPSVsyncOnly, paces frames in software, via paceFrame, against
+	// the monitor's reported refresh rate. This avoids
+	// the latency of the driver's vsync wait while still capping the frame
+	// rate to something sensible for the display.
+	SwapIntervalPacedUncapped = -2
+)
+
+// SwapInterval returns the value last passed to SetSwapInterval.
+func (u *UserInterface) SwapInterval() int {
+	if !u.isRunning() {
+		u.m.RLock()
+		defer u.m.RUnlock()
+		return u.swapInterval
+	}
+	var v int
+	_ = u.t.Call(func() error {
+		v = u.swapInterval
+		return nil
+	})
+	return v
+}
+
+// SetSwapInterval sets the buffer swap interval to use while vsync is
+// enabled (see SetVsyncEnabled): the default of 1 waits for every vertical
+// blank, SwapIntervalAdaptive requests adaptive vsync, a value > 1 waits for
+// that many vertical blanks (e.g. 2 for half the monitor's refresh rate),
+// and SwapIntervalPacedUncapped turns the driver's wait off entirely and
+// paces frames against the monitor's refresh rate instead.
+//
+// SetSwapInterval has no effect while vsync is disabled via
+// SetVsyncEnabled(false); that turns the driver's wait off as
+// SwapIntervalPacedUncapped does, except that TargetFPSVsyncOnly then paces
+// at 60fps rather than at the monitor's refresh rate.
+//
+// TODO: expose this through driver.UIContext and the ebiten package, and give
+// driver.Graphics implementations a SetSwapInterval method so it actually
+// reaches a backend; u.Graphics().(interface{ SetSwapInterval(int) }) has
+// nothing to find yet.
+func (u *UserInterface) SetSwapInterval(interval int) {
+	if !u.isRunning() {
+		u.m.Lock()
+		u.swapInterval = interval
+		u.m.Unlock()
+		return
+	}
+	_ = u.t.Call(func() error {
+		u.swapInterval = interval
+		u.updateVsync()
+		u.framePacer.reset()
+		return nil
+	})
+}